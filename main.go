@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +22,104 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+const version = "0.1.0"
+
+var userAgent = fmt.Sprintf("tstats/%s", version)
+
+// httpClient is shared by every backend so a hung DNS lookup or a slow API
+// can't freeze the TUI forever.
+var httpClient = &http.Client{Timeout: 5 * time.Second}
+
+// retryDelays are the backoffs between retry attempts on a 5xx response or
+// network error: 200ms, 500ms, 1s (3 retries on top of the initial attempt).
+var retryDelays = []time.Duration{200 * time.Millisecond, 500 * time.Millisecond, time.Second}
+
+// cacheDir is the directory every *CacheFilePath function writes into. It's
+// resolved once in main from --cache-dir/$XDG_CACHE_HOME (the cache
+// subcommand resolves its own directory and does not set it) and stashed here so the cache helpers below don't need it threaded through
+// every call site.
+var cacheDir string
+
+// defaultCacheDir returns $XDG_CACHE_HOME/tstats, falling back to
+// ~/.cache/tstats per the XDG Base Directory spec.
+func defaultCacheDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "tstats"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "tstats"), nil
+}
+
+// resolveCacheDir honors an explicit --cache-dir override, falling back to
+// defaultCacheDir.
+func resolveCacheDir(override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	return defaultCacheDir()
+}
+
+// writeFileAtomic writes data to path by writing to a temp file in the same
+// directory and renaming it into place, so a crash or kill mid-write can
+// never leave a truncated file that fails json.Unmarshal on the next run.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// httpGetWithRetry issues a GET request with the shared client and tstats's
+// User-Agent, retrying with backoff on 5xx responses and network errors.
+// ctx cancellation (e.g. the user pressing q) aborts both in-flight requests
+// and any pending backoff wait.
+func httpGetWithRetry(ctx context.Context, rawURL string) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", userAgent)
+
+		resp, err := httpClient.Do(req)
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.StatusCode >= 500:
+			resp.Body.Close()
+			lastErr = fmt.Errorf("server returned %s", resp.Status)
+		default:
+			return resp, nil
+		}
+
+		if attempt >= len(retryDelays) {
+			return nil, lastErr
+		}
+		select {
+		case <-time.After(retryDelays[attempt]):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 // --- Structs for API Responses ---
 
 type WeatherResponse struct {
@@ -25,6 +127,8 @@ type WeatherResponse struct {
 	Longitude    float64      `json:"longitude"`
 	CurrentUnits CurrentUnits `json:"current_units"`
 	Current      CurrentData  `json:"current"`
+	Hourly       HourlyData   `json:"hourly"`
+	Daily        DailyData    `json:"daily"`
 }
 
 type CurrentUnits struct {
@@ -36,6 +140,128 @@ type CurrentData struct {
 	Time          string  `json:"time"`
 	Temperature2m float64 `json:"temperature_2m"`
 	WeatherCode   int     `json:"weather_code"`
+	FeelsLike     float64 `json:"apparent_temperature,omitempty"`
+	Humidity      float64 `json:"relative_humidity_2m,omitempty"`
+	WindSpeed10m  float64 `json:"wind_speed_10m,omitempty"`
+	Description   string  `json:"description,omitempty"`
+}
+
+// HourlyData and DailyData carry Open-Meteo's forecast arrays, one entry per
+// hour/day. Backends that don't support forecasts (owm, wttr) simply leave
+// these zero-valued; HourlyPeriods/DailyPeriods then return no periods and
+// the forecast views degrade gracefully.
+type HourlyData struct {
+	Time                     []string  `json:"time"`
+	Temperature2m            []float64 `json:"temperature_2m"`
+	WeatherCode              []int     `json:"weather_code"`
+	PrecipitationProbability []int     `json:"precipitation_probability"`
+	WindSpeed10m             []float64 `json:"wind_speed_10m"`
+}
+
+type DailyData struct {
+	Time             []string  `json:"time"`
+	Temperature2mMax []float64 `json:"temperature_2m_max"`
+	Temperature2mMin []float64 `json:"temperature_2m_min"`
+	WeatherCode      []int     `json:"weather_code"`
+	Sunrise          []string  `json:"sunrise"`
+	Sunset           []string  `json:"sunset"`
+}
+
+// WeatherPeriod is a single point on a forecast timeline, normalized from
+// either HourlyData or DailyData so View() can render both the same way.
+type WeatherPeriod struct {
+	Label         string // "14:00" for hourly, "Mon" for daily
+	Temperature   float64
+	TempMin       float64 // daily only
+	TempMax       float64 // daily only
+	WeatherCode   int
+	Precipitation int // percent, hourly only
+}
+
+// HourlyPeriods returns up to n upcoming hourly periods, or an empty slice if
+// the backend that produced w didn't populate Hourly. Open-Meteo's hourly array
+// starts at 00:00 of the current day and isn't anchored to "now", so this
+// first skips past hours that have already elapsed.
+func (w *WeatherResponse) HourlyPeriods(n int) []WeatherPeriod {
+	start := hourlyStartIndex(w.Hourly.Time, w.Current.Time)
+	count := len(w.Hourly.Time) - start
+	if count > n {
+		count = n
+	}
+	periods := make([]WeatherPeriod, 0, count)
+	for i := start; i < start+count; i++ {
+		label := w.Hourly.Time[i]
+		if t, err := time.Parse("2006-01-02T15:04", label); err == nil {
+			label = t.Format("15:04")
+		}
+		period := WeatherPeriod{Label: label}
+		if i < len(w.Hourly.Temperature2m) {
+			period.Temperature = w.Hourly.Temperature2m[i]
+		}
+		if i < len(w.Hourly.WeatherCode) {
+			period.WeatherCode = w.Hourly.WeatherCode[i]
+		}
+		if i < len(w.Hourly.PrecipitationProbability) {
+			period.Precipitation = w.Hourly.PrecipitationProbability[i]
+		}
+		periods = append(periods, period)
+	}
+	return periods
+}
+
+// hourlyStartIndex returns the index of the first entry in times (formatted
+// "2006-01-02T15:04", as Open-Meteo's hourly timestamps are) that is not
+// before the current hour, or len(times) if every entry is already in the
+// past. times and currentTime both carry no timezone info of their own (the
+// API was asked for timezone=auto, which resolves to the queried location's
+// zone, not the host's), so "now" must come from currentTime — pass
+// WeatherResponse.Current.Time, fetched in the same call and the same
+// zoneless local time as times — rather than the host clock, which is
+// usually in a different zone than the queried location.
+func hourlyStartIndex(times []string, currentTime string) int {
+	now, err := time.Parse("2006-01-02T15:04", currentTime)
+	if err != nil {
+		return 0
+	}
+	now = now.Truncate(time.Hour)
+	for i, ts := range times {
+		parsed, err := time.Parse("2006-01-02T15:04", ts)
+		if err != nil {
+			continue
+		}
+		if !parsed.Before(now) {
+			return i
+		}
+	}
+	return len(times)
+}
+
+// DailyPeriods returns up to n upcoming daily periods, or an empty slice if
+// the backend that produced w didn't populate Daily.
+func (w *WeatherResponse) DailyPeriods(n int) []WeatherPeriod {
+	count := len(w.Daily.Time)
+	if count > n {
+		count = n
+	}
+	periods := make([]WeatherPeriod, 0, count)
+	for i := 0; i < count; i++ {
+		label := w.Daily.Time[i]
+		if t, err := time.Parse("2006-01-02", label); err == nil {
+			label = t.Format("Mon")
+		}
+		period := WeatherPeriod{Label: label}
+		if i < len(w.Daily.Temperature2mMax) {
+			period.TempMax = w.Daily.Temperature2mMax[i]
+		}
+		if i < len(w.Daily.Temperature2mMin) {
+			period.TempMin = w.Daily.Temperature2mMin[i]
+		}
+		if i < len(w.Daily.WeatherCode) {
+			period.WeatherCode = w.Daily.WeatherCode[i]
+		}
+		periods = append(periods, period)
+	}
+	return periods
 }
 
 type GeoInfo struct {
@@ -48,6 +274,542 @@ type GeoInfo struct {
 	Query   string  `json:"query"`
 }
 
+// --- Cache Envelopes ---
+//
+// The raw API responses are wrapped with a fetch timestamp before being
+// written to disk, so checkCacheCmd can tell a stale cache from a fresh one
+// without relying solely on file modification time.
+
+type geoCacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	GeoInfo   GeoInfo   `json:"geo_info"`
+}
+
+type weatherCacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Weather   WeatherResponse `json:"weather"`
+}
+
+// --- Weather Backends ---
+//
+// WeatherBackend lets getWeatherInfo fetch current conditions from whichever
+// provider the user selected with --backend, normalizing each provider's
+// response shape into the WeatherResponse struct above.
+
+type WeatherBackend interface {
+	Name() string
+	Fetch(ctx context.Context, lat, lon float64) (*WeatherResponse, error)
+}
+
+type openMeteoBackend struct{}
+
+func (openMeteoBackend) Name() string { return "openmeteo" }
+
+func (openMeteoBackend) Fetch(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	url := fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f"+
+			"&current=temperature_2m,weather_code,relative_humidity_2m,apparent_temperature,wind_speed_10m"+
+			"&hourly=temperature_2m,weather_code,precipitation_probability,wind_speed_10m"+
+			"&daily=temperature_2m_max,temperature_2m_min,weather_code,sunrise,sunset"+
+			"&timezone=auto",
+		lat, lon,
+	)
+	resp, err := httpGetWithRetry(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather API returned an unexpected status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var weatherData WeatherResponse
+	if err := json.Unmarshal(body, &weatherData); err != nil {
+		return nil, fmt.Errorf("error decoding weather data: %w", err)
+	}
+	return &weatherData, nil
+}
+
+// owmBackend queries OpenWeatherMap's current-weather endpoint. It requires
+// an API key, supplied via --api-key or the OWM_API_KEY environment variable.
+type owmBackend struct {
+	APIKey string
+}
+
+func (owmBackend) Name() string { return "owm" }
+
+type owmResponse struct {
+	Weather []struct {
+		ID          int    `json:"id"`
+		Description string `json:"description"`
+	} `json:"weather"`
+	Main struct {
+		Temp      float64 `json:"temp"`
+		FeelsLike float64 `json:"feels_like"`
+		Humidity  float64 `json:"humidity"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Dt int64 `json:"dt"`
+}
+
+func (b owmBackend) Fetch(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	if b.APIKey == "" {
+		return nil, fmt.Errorf("owm backend requires an API key (--api-key or OWM_API_KEY)")
+	}
+	url := fmt.Sprintf("https://api.openweathermap.org/data/2.5/weather?lat=%f&lon=%f&units=metric&appid=%s", lat, lon, b.APIKey)
+	resp, err := httpGetWithRetry(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenWeatherMap API returned an unexpected status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var owmResp owmResponse
+	if err := json.Unmarshal(body, &owmResp); err != nil {
+		return nil, fmt.Errorf("error decoding OpenWeatherMap data: %w", err)
+	}
+
+	current := CurrentData{
+		Time:          time.Unix(owmResp.Dt, 0).UTC().Format(time.RFC3339),
+		Temperature2m: owmResp.Main.Temp,
+		FeelsLike:     owmResp.Main.FeelsLike,
+		Humidity:      owmResp.Main.Humidity,
+		// OpenWeatherMap's units=metric reports wind speed in m/s; every other
+		// backend (and the WindSpeed10m field name, matching Open-Meteo) uses
+		// km/h, so convert to keep the field's unit consistent across backends.
+		WindSpeed10m: owmResp.Wind.Speed * 3.6,
+	}
+	if len(owmResp.Weather) > 0 {
+		current.WeatherCode = owmConditionToWMO(owmResp.Weather[0].ID)
+		current.Description = owmResp.Weather[0].Description
+	}
+
+	return &WeatherResponse{
+		Latitude:     lat,
+		Longitude:    lon,
+		CurrentUnits: CurrentUnits{Temperature2m: "°C", WeatherCode: "wmo"},
+		Current:      current,
+	}, nil
+}
+
+// owmConditionToWMO maps OpenWeatherMap's condition IDs (grouped by their
+// leading digit, see https://openweathermap.org/weather-conditions) onto the
+// WMO weather codes Open-Meteo uses, so wmoCodeToEmoji and the JSON output
+// don't need to know which backend produced the reading.
+func owmConditionToWMO(id int) int {
+	switch {
+	case id == 800:
+		return 0 // clear sky
+	case id == 801 || id == 802:
+		return 2 // partly cloudy
+	case id == 803 || id == 804:
+		return 3 // overcast
+	case id >= 200 && id < 300:
+		return 95 // thunderstorm
+	case id >= 300 && id < 400:
+		return 51 // drizzle
+	case id >= 500 && id < 600:
+		return 61 // rain
+	case id >= 600 && id < 700:
+		return 71 // snow
+	case id >= 700 && id < 800:
+		return 45 // fog/atmosphere
+	default:
+		return 0
+	}
+}
+
+// wttrBackend queries wttr.in's JSON output (?format=j1), which requires no
+// API key and is handy when OWM/Open-Meteo are rate-limited.
+type wttrBackend struct{}
+
+func (wttrBackend) Name() string { return "wttr" }
+
+type wttrResponse struct {
+	CurrentCondition []struct {
+		TempC         string `json:"temp_C"`
+		FeelsLikeC    string `json:"FeelsLikeC"`
+		Humidity      string `json:"humidity"`
+		WindspeedKmph string `json:"windspeedKmph"`
+		WeatherCode   string `json:"weatherCode"`
+		WeatherDesc   []struct {
+			Value string `json:"value"`
+		} `json:"weatherDesc"`
+	} `json:"current_condition"`
+}
+
+func (wttrBackend) Fetch(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	url := fmt.Sprintf("https://wttr.in/%f,%f?format=j1", lat, lon)
+	// httpGetWithRetry sends tstats's own User-Agent, which wttr.in accepts
+	// (it's Go's unadorned default UA that gets rejected).
+	resp, err := httpGetWithRetry(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wttr.in returned an unexpected status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var wttrResp wttrResponse
+	if err := json.Unmarshal(body, &wttrResp); err != nil {
+		return nil, fmt.Errorf("error decoding wttr.in data: %w", err)
+	}
+	if len(wttrResp.CurrentCondition) == 0 {
+		return nil, fmt.Errorf("wttr.in response had no current_condition")
+	}
+	cc := wttrResp.CurrentCondition[0]
+
+	current := CurrentData{
+		Time:          time.Now().UTC().Format(time.RFC3339),
+		Temperature2m: parseFloatOrZero(cc.TempC),
+		FeelsLike:     parseFloatOrZero(cc.FeelsLikeC),
+		Humidity:      parseFloatOrZero(cc.Humidity),
+		WindSpeed10m:  parseFloatOrZero(cc.WindspeedKmph),
+		WeatherCode:   wttrCodeToWMO(cc.WeatherCode),
+	}
+	if len(cc.WeatherDesc) > 0 {
+		current.Description = cc.WeatherDesc[0].Value
+	}
+
+	return &WeatherResponse{
+		Latitude:     lat,
+		Longitude:    lon,
+		CurrentUnits: CurrentUnits{Temperature2m: "°C", WeatherCode: "wmo"},
+		Current:      current,
+	}, nil
+}
+
+// wttrCodeToWMO maps wttr.in's (worldweatheronline) condition codes for the
+// most common conditions onto WMO codes. Codes not in the table fall back to
+// 0, the WMO code for clear sky, so they read as clear; the human-readable
+// Description is always set regardless.
+func wttrCodeToWMO(code string) int {
+	switch code {
+	case "113":
+		return 0 // clear/sunny
+	case "116":
+		return 2 // partly cloudy
+	case "119", "122":
+		return 3 // cloudy/overcast
+	case "143", "248", "260":
+		return 45 // mist/fog
+	case "176", "263", "266", "293", "296":
+		return 51 // light drizzle/rain
+	case "299", "302", "305", "308":
+		return 61 // rain
+	case "323", "326", "329", "332", "335", "338":
+		return 71 // snow
+	case "200", "386", "389", "392", "395":
+		return 95 // thunder
+	default:
+		return 0
+	}
+}
+
+func parseFloatOrZero(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// selectWeatherBackend builds the WeatherBackend named by --backend.
+func selectWeatherBackend(name, apiKey string) (WeatherBackend, error) {
+	switch name {
+	case "", "openmeteo":
+		return openMeteoBackend{}, nil
+	case "owm":
+		return owmBackend{APIKey: apiKey}, nil
+	case "wttr":
+		return wttrBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown weather backend %q (want openmeteo, owm, or wttr)", name)
+	}
+}
+
+// --- Geolocation Backends ---
+//
+// GeoBackend mirrors WeatherBackend for IP geolocation, so users can switch
+// providers via --geo-backend when one is rate-limited.
+
+type GeoBackend interface {
+	Name() string
+	Fetch(ctx context.Context, ip string) (*GeoInfo, error)
+}
+
+type ipAPIBackend struct{}
+
+func (ipAPIBackend) Name() string { return "ipapi" }
+
+func (ipAPIBackend) Fetch(ctx context.Context, ip string) (*GeoInfo, error) {
+	url := fmt.Sprintf("http://ip-api.com/json/%s", ip)
+	resp, err := httpGetWithRetry(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var geoInfo GeoInfo
+	if err := json.Unmarshal(body, &geoInfo); err != nil {
+		return nil, err
+	}
+	if geoInfo.Status != "success" {
+		return nil, fmt.Errorf("geolocation API failed with status: %s", geoInfo.Status)
+	}
+	return &geoInfo, nil
+}
+
+type ipInfoBackend struct{}
+
+func (ipInfoBackend) Name() string { return "ipinfo" }
+
+type ipInfoResponse struct {
+	IP      string `json:"ip"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+	Org     string `json:"org"`
+	Loc     string `json:"loc"` // "lat,lon"
+}
+
+func (ipInfoBackend) Fetch(ctx context.Context, ip string) (*GeoInfo, error) {
+	url := fmt.Sprintf("https://ipinfo.io/%s/json", ip)
+	resp, err := httpGetWithRetry(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var ipInfoResp ipInfoResponse
+	if err := json.Unmarshal(body, &ipInfoResp); err != nil {
+		return nil, err
+	}
+	lat, lon, err := parseLatLon(ipInfoResp.Loc)
+	if err != nil {
+		return nil, fmt.Errorf("ipinfo.io returned an unparseable location %q: %w", ipInfoResp.Loc, err)
+	}
+	return &GeoInfo{
+		Status:  "success",
+		Country: ipInfoResp.Country,
+		City:    ipInfoResp.City,
+		Lat:     lat,
+		Lon:     lon,
+		ISP:     ipInfoResp.Org,
+		Query:   ipInfoResp.IP,
+	}, nil
+}
+
+type ipWhoIsBackend struct{}
+
+func (ipWhoIsBackend) Name() string { return "ipwhois" }
+
+type ipWhoIsResponse struct {
+	Success    bool    `json:"success"`
+	IP         string  `json:"ip"`
+	City       string  `json:"city"`
+	Country    string  `json:"country"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Connection struct {
+		ISP string `json:"isp"`
+	} `json:"connection"`
+}
+
+func (ipWhoIsBackend) Fetch(ctx context.Context, ip string) (*GeoInfo, error) {
+	url := fmt.Sprintf("https://ipwhois.app/json/%s", ip)
+	resp, err := httpGetWithRetry(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var whoIsResp ipWhoIsResponse
+	if err := json.Unmarshal(body, &whoIsResp); err != nil {
+		return nil, err
+	}
+	if !whoIsResp.Success {
+		return nil, fmt.Errorf("ipwhois.app reported failure for IP %s", ip)
+	}
+	return &GeoInfo{
+		Status:  "success",
+		Country: whoIsResp.Country,
+		City:    whoIsResp.City,
+		Lat:     whoIsResp.Latitude,
+		Lon:     whoIsResp.Longitude,
+		ISP:     whoIsResp.Connection.ISP,
+		Query:   whoIsResp.IP,
+	}, nil
+}
+
+func parseLatLon(loc string) (float64, float64, error) {
+	parts := strings.SplitN(loc, ",", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected \"lat,lon\"")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lat, lon, nil
+}
+
+// selectGeoBackend builds the GeoBackend named by --geo-backend.
+func selectGeoBackend(name string) (GeoBackend, error) {
+	switch name {
+	case "", "ipapi":
+		return ipAPIBackend{}, nil
+	case "ipinfo":
+		return ipInfoBackend{}, nil
+	case "ipwhois":
+		return ipWhoIsBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown geo backend %q (want ipapi, ipinfo, or ipwhois)", name)
+	}
+}
+
+// --- Manual Location Override ---
+//
+// locationOverride lets the user skip IP-based geolocation entirely, either
+// with coordinates they already know (--lat/--lon or --coords) or with a
+// place name resolved via Photon's geocoding API (--location).
+
+type locationOverride struct {
+	geoInfo *GeoInfo // set directly from --lat/--lon or --coords; nil if query is used instead
+	query   string   // set from --location; resolved asynchronously via fetchGeocodeCmd
+}
+
+type geocodeCacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	GeoInfo   GeoInfo   `json:"geo_info"`
+}
+
+type photonResponse struct {
+	Features []struct {
+		Geometry struct {
+			Coordinates []float64 `json:"coordinates"` // [lon, lat]
+		} `json:"geometry"`
+		Properties struct {
+			Name    string `json:"name"`
+			City    string `json:"city"`
+			Country string `json:"country"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+func fetchGeocodeCmd(ctx context.Context, query string) tea.Cmd {
+	return func() tea.Msg {
+		geoInfo, err := fetchGeocode(ctx, query)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return geoInfoFetchedMsg(geoInfo)
+	}
+}
+
+// fetchGeocode resolves a place name to coordinates via Photon, caching the
+// match to disk so re-running tstats with the same --location doesn't need a
+// network round trip (place names don't move).
+func fetchGeocode(ctx context.Context, query string) (*GeoInfo, error) {
+	cacheFile := geocodeCacheFilePath(query)
+	if cached, err := os.ReadFile(cacheFile); err == nil {
+		var entry geocodeCacheEntry
+		if json.Unmarshal(cached, &entry) == nil {
+			return &entry.GeoInfo, nil
+		}
+	}
+
+	apiURL := fmt.Sprintf("https://photon.komoot.io/api/?q=%s&limit=1", url.QueryEscape(query))
+	resp, err := httpGetWithRetry(ctx, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocoding API returned an unexpected status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response body: %w", err)
+	}
+	var photonResp photonResponse
+	if err := json.Unmarshal(body, &photonResp); err != nil {
+		return nil, fmt.Errorf("error decoding geocoding data: %w", err)
+	}
+	if len(photonResp.Features) == 0 {
+		return nil, fmt.Errorf("no location found for %q", query)
+	}
+	feature := photonResp.Features[0]
+	if len(feature.Geometry.Coordinates) != 2 {
+		return nil, fmt.Errorf("geocoding API returned an unexpected geometry for %q", query)
+	}
+
+	city := feature.Properties.City
+	if city == "" {
+		city = feature.Properties.Name
+	}
+	geoInfo := &GeoInfo{
+		Status:  "success",
+		Country: feature.Properties.Country,
+		City:    city,
+		Lat:     feature.Geometry.Coordinates[1],
+		Lon:     feature.Geometry.Coordinates[0],
+		Query:   query,
+	}
+
+	entry := geocodeCacheEntry{FetchedAt: time.Now(), GeoInfo: *geoInfo}
+	if cacheBody, err := json.Marshal(entry); err != nil {
+		log.Printf("warning: failed to marshal geocode cache: %v", err)
+	} else if err := writeFileAtomic(cacheFile, cacheBody, 0644); err != nil {
+		log.Printf("warning: failed to write geocode cache: %v", err)
+	}
+	return geoInfo, nil
+}
+
+func geocodeCacheFilePath(query string) string {
+	return filepath.Join(cacheDir, fmt.Sprintf("geocode_cache_%s.json", slugify(query)))
+}
+
+func slugify(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
 // --- Bubble Tea Messages & Model ---
 
 type cacheCheckResultMsg struct {
@@ -61,17 +823,25 @@ type weatherFetchedMsg *WeatherResponse
 type errorMsg struct{ err error }
 
 type model struct {
-	steps        []string
-	index        int
-	spinner      spinner.Model
-	progress     progress.Model
-	width        int
-	height       int
-	done         bool
-	err          error
-	geoInfo      *GeoInfo
-	weatherData  *WeatherResponse
-	forceRefresh bool
+	ctx            context.Context
+	cancel         context.CancelFunc
+	steps          []string
+	index          int
+	spinner        spinner.Model
+	progress       progress.Model
+	width          int
+	height         int
+	done           bool
+	err            error
+	geoInfo        *GeoInfo
+	weatherData    *WeatherResponse
+	forceRefresh   bool
+	geoTTL         time.Duration
+	weatherTTL     time.Duration
+	geoBackend     GeoBackend
+	weatherBackend WeatherBackend
+	override       *locationOverride
+	forecastMode   string
 }
 
 // --- Bubble Tea Styling ---
@@ -111,12 +881,21 @@ func getTempColor(temp float64) lipgloss.Style {
 
 // --- Bubble Tea App ---
 
-func newModel(forceRefresh bool) model {
-	steps := []string{
-		"Checking local cache...",
-		"Fetching public IP...",
-		"Fetching geolocation data...",
-		"Fetching weather forecast...",
+func newModel(ctx context.Context, cancel context.CancelFunc, forceRefresh bool, geoTTL, weatherTTL time.Duration, geoBackend GeoBackend, weatherBackend WeatherBackend, override *locationOverride, forecastMode string) model {
+	var steps []string
+	switch {
+	case override != nil && override.geoInfo != nil:
+		// Coordinates already known: no cache check, no IP lookup, no geocoding.
+		steps = []string{"Fetching weather forecast..."}
+	case override != nil && override.query != "":
+		steps = []string{"Resolving location...", "Fetching weather forecast..."}
+	default:
+		steps = []string{
+			"Checking local cache...",
+			"Fetching public IP...",
+			"Fetching geolocation data...",
+			"Fetching weather forecast...",
+		}
 	}
 
 	p := progress.New(
@@ -127,16 +906,37 @@ func newModel(forceRefresh bool) model {
 	s := spinner.New()
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
 
+	var initialGeoInfo *GeoInfo
+	if override != nil {
+		initialGeoInfo = override.geoInfo
+	}
+
 	return model{
-		steps:        steps,
-		spinner:      s,
-		progress:     p,
-		forceRefresh: forceRefresh,
+		ctx:            ctx,
+		cancel:         cancel,
+		steps:          steps,
+		spinner:        s,
+		progress:       p,
+		forceRefresh:   forceRefresh,
+		geoTTL:         geoTTL,
+		weatherTTL:     weatherTTL,
+		geoBackend:     geoBackend,
+		weatherBackend: weatherBackend,
+		override:       override,
+		geoInfo:        initialGeoInfo,
+		forecastMode:   forecastMode,
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(checkCacheCmd(m.forceRefresh), m.spinner.Tick)
+	switch {
+	case m.override != nil && m.override.geoInfo != nil:
+		return tea.Batch(fetchWeatherInfoNoCacheCmd(m.ctx, m.override.geoInfo, m.weatherBackend), m.spinner.Tick)
+	case m.override != nil && m.override.query != "":
+		return tea.Batch(fetchGeocodeCmd(m.ctx, m.override.query), m.spinner.Tick)
+	default:
+		return tea.Batch(checkCacheCmd(m.forceRefresh, m.geoTTL, m.weatherTTL, m.geoBackend, m.weatherBackend), m.spinner.Tick)
+	}
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -148,6 +948,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "esc", "q":
+			m.cancel()
 			return m, tea.Quit
 		}
 
@@ -167,13 +968,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				quitCmd,
 			)
 		}
+		if msg.geoInfo != nil {
+			// Geo cache is still fresh; only the weather cache expired.
+			// Skip the IP/geolocation steps entirely and fetch weather
+			// directly with the cached geoInfo.
+			m.geoInfo = msg.geoInfo
+			m.steps = []string{"Checking local cache...", "Fetching weather forecast..."}
+			m.index++
+			progressCmd := m.progress.SetPercent(float64(m.index) / float64(len(m.steps)))
+			return m, tea.Batch(
+				progressCmd,
+				tea.Printf("%s Using cached geolocation for %s", checkMark, msg.geoInfo.City),
+				fetchWeatherInfoCmd(m.ctx, msg.geoInfo, m.weatherBackend),
+			)
+		}
 		// Cache miss, proceed to the next step
 		m.index++
 		progressCmd := m.progress.SetPercent(float64(m.index) / float64(len(m.steps)))
 		return m, tea.Batch(
 			progressCmd,
 			tea.Printf("%s Cache not found or invalid", checkMark),
-			fetchPublicIPCmd(),
+			fetchPublicIPCmd(m.ctx),
 		)
 
 	case ipFetchedMsg:
@@ -182,17 +997,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(
 			progressCmd,
 			tea.Printf("%s Public IP fetched", checkMark),
-			fetchGeoInfoCmd(string(msg)),
+			fetchGeoInfoCmd(m.ctx, string(msg), m.geoBackend),
 		)
 
 	case geoInfoFetchedMsg:
 		m.index++
 		m.geoInfo = msg
 		progressCmd := m.progress.SetPercent(float64(m.index) / float64(len(m.steps)))
+		if m.override != nil {
+			// Resolved via --location/fetchGeocodeCmd: weather must not be
+			// written through the shared per-backend cache (see
+			// getWeatherInfoNoCache).
+			return m, tea.Batch(
+				progressCmd,
+				tea.Printf("%s Location resolved to %s", checkMark, msg.City),
+				fetchWeatherInfoNoCacheCmd(m.ctx, msg, m.weatherBackend),
+			)
+		}
 		return m, tea.Batch(
 			progressCmd,
 			tea.Printf("%s Geolocation for %s fetched and cached", checkMark, msg.City),
-			fetchWeatherInfoCmd(msg),
+			fetchWeatherInfoCmd(m.ctx, msg, m.weatherBackend),
 		)
 
 	case weatherFetchedMsg:
@@ -244,8 +1069,14 @@ func (m model) View() string {
 		city := cityStyle.Render(m.geoInfo.City)
 		temp := tempStyle.Render(fmt.Sprintf("%.1f°C", m.weatherData.Current.Temperature2m))
 
-		weatherResult := fmt.Sprintf("\n%s — %s\n", city, temp)
-		return doneStyle.Render(weatherResult)
+		sections := []string{fmt.Sprintf("\n%s — %s\n", city, temp)}
+		if m.forecastMode == "hourly" || m.forecastMode == "all" {
+			sections = append(sections, m.renderHourlyTimeline())
+		}
+		if m.forecastMode == "daily" || m.forecastMode == "all" {
+			sections = append(sections, m.renderDailyTimeline())
+		}
+		return doneStyle.Render(strings.Join(sections, "\n"))
 	}
 
 	n := len(m.steps)
@@ -262,51 +1093,169 @@ func (m model) View() string {
 	return spin + info + gap + prog + pkgCount
 }
 
+// timelineWidth picks the box width a forecast timeline should render at,
+// falling back to a sane default before the first WindowSizeMsg arrives.
+func (m model) timelineWidth() int {
+	if m.width > 0 {
+		return m.width
+	}
+	return 60
+}
+
+var timelineBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+
+// hourlyCellWidth is the column width ("%.1f°C" plus padding) each hour
+// takes up in renderHourlyTimeline.
+const hourlyCellWidth = 7
+
+// maxHourlyPeriods is the most hours renderHourlyTimeline will ever show,
+// even on a very wide terminal.
+const maxHourlyPeriods = 12
+
+// renderHourlyTimeline renders up to the next 12 hours as a horizontal table
+// of time / temperature / precipitation-chance columns, showing as many
+// hours as fit in timelineBoxStyle at the current terminal width rather than
+// always rendering a fixed 12 columns.
+func (m model) renderHourlyTimeline() string {
+	// The box is rendered at timelineWidth()-4, which includes its 1-cell
+	// horizontal padding on each side, so the cells get 2 columns less.
+	hours := (m.timelineWidth() - 4 - 2) / hourlyCellWidth
+	if hours > maxHourlyPeriods {
+		hours = maxHourlyPeriods
+	}
+	if hours < 1 {
+		hours = 1
+	}
+
+	periods := m.weatherData.HourlyPeriods(hours)
+	if len(periods) == 0 {
+		return lipgloss.NewStyle().Faint(true).Render("Hourly Forecast: unavailable for this backend")
+	}
+
+	var times, temps, precips strings.Builder
+	for _, p := range periods {
+		times.WriteString(fmt.Sprintf("%*s", hourlyCellWidth, p.Label))
+		temps.WriteString(getTempColor(p.Temperature).Render(fmt.Sprintf("%*s", hourlyCellWidth, fmt.Sprintf("%.1f°C", p.Temperature))))
+		precips.WriteString(fmt.Sprintf("%*s", hourlyCellWidth, fmt.Sprintf("%d%%", p.Precipitation)))
+	}
+
+	body := strings.Join([]string{times.String(), temps.String(), precips.String()}, "\n")
+	title := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%d-Hour Forecast", len(periods)))
+	return title + "\n" + timelineBoxStyle.Width(m.timelineWidth()-4).Render(body)
+}
+
+// renderDailyTimeline renders the next 3 days as a horizontal table of
+// day / low-high temperature columns.
+func (m model) renderDailyTimeline() string {
+	periods := m.weatherData.DailyPeriods(3)
+	if len(periods) == 0 {
+		return lipgloss.NewStyle().Faint(true).Render("3-Day Forecast: unavailable for this backend")
+	}
+
+	const cellWidth = 14
+	var days, temps strings.Builder
+	for _, p := range periods {
+		days.WriteString(fmt.Sprintf("%*s", cellWidth, p.Label))
+		rangeStr := fmt.Sprintf("%.0f°C - %.0f°C", p.TempMin, p.TempMax)
+		temps.WriteString(getTempColor(p.TempMax).Render(fmt.Sprintf("%*s", cellWidth, rangeStr)))
+	}
+
+	body := strings.Join([]string{days.String(), temps.String()}, "\n")
+	title := lipgloss.NewStyle().Bold(true).Render("3-Day Forecast")
+	return title + "\n" + timelineBoxStyle.Width(m.timelineWidth()-4).Render(body)
+}
+
 // --- Commands and Logic ---
 
-func checkCacheCmd(forceRefresh bool) tea.Cmd {
+func checkCacheCmd(forceRefresh bool, geoTTL, weatherTTL time.Duration, geoBackend GeoBackend, weatherBackend WeatherBackend) tea.Cmd {
 	return func() tea.Msg {
-		geoCacheFile := filepath.Join(os.TempDir(), "geoinfo_cache.json")
-		weatherCacheFile := filepath.Join(os.TempDir(), "weather_cache.json")
+		return checkCache(forceRefresh, geoTTL, weatherTTL, geoBackend, weatherBackend)
+	}
+}
 
-		if forceRefresh {
-			os.Remove(geoCacheFile)
-			os.Remove(weatherCacheFile)
-			return cacheCheckResultMsg{hasCache: false}
-		}
+// checkCache is the synchronous core of checkCacheCmd, factored out so the
+// headless output modes can reuse it without going through Bubble Tea.
+//
+// The geo and weather TTLs expire independently (geo is long-lived, e.g.
+// 24h, since IP geolocation rarely changes; weather is short, e.g. 10m), so
+// a result with a valid geoInfo but no weatherData is possible: the caller
+// should skip straight to fetching weather with the cached geoInfo instead
+// of discarding it and redoing the IP/geolocation round-trip.
+func checkCache(forceRefresh bool, geoTTL, weatherTTL time.Duration, geoBackend GeoBackend, weatherBackend WeatherBackend) cacheCheckResultMsg {
+	geoCacheFile := geoCacheFilePath(geoBackend)
+	weatherCacheFile := weatherCacheFilePath(weatherBackend)
 
-		// Check for GeoInfo cache
-		geoData, err := os.ReadFile(geoCacheFile)
-		if err != nil {
-			return cacheCheckResultMsg{hasCache: false}
-		}
-		var geoInfo GeoInfo
-		if json.Unmarshal(geoData, &geoInfo) != nil || geoInfo.Status != "success" {
-			return cacheCheckResultMsg{hasCache: false}
-		}
+	if forceRefresh {
+		os.Remove(geoCacheFile)
+		os.Remove(weatherCacheFile)
+		return cacheCheckResultMsg{hasCache: false}
+	}
 
-		// Check for Weather cache
-		weatherData, err := os.ReadFile(weatherCacheFile)
-		if err != nil {
-			return cacheCheckResultMsg{hasCache: false}
-		}
-		var weatherResp WeatherResponse
-		if json.Unmarshal(weatherData, &weatherResp) != nil {
-			return cacheCheckResultMsg{hasCache: false}
-		}
+	// Check for GeoInfo cache
+	geoData, err := os.ReadFile(geoCacheFile)
+	if err != nil {
+		return cacheCheckResultMsg{hasCache: false}
+	}
+	var geoEntry geoCacheEntry
+	if json.Unmarshal(geoData, &geoEntry) != nil || geoEntry.GeoInfo.Status != "success" {
+		return cacheCheckResultMsg{hasCache: false}
+	}
+	if cacheExpired(geoCacheFile, geoEntry.FetchedAt, geoTTL) {
+		return cacheCheckResultMsg{hasCache: false}
+	}
+
+	// Geo cache is valid. Even if the weather cache below turns out to be
+	// missing or expired, this geoInfo is still worth returning: it lets the
+	// caller skip the IP/geolocation steps and fetch weather directly.
+	geoInfo := &geoEntry.GeoInfo
+
+	// Check for Weather cache
+	weatherData, err := os.ReadFile(weatherCacheFile)
+	if err != nil {
+		return cacheCheckResultMsg{hasCache: false, geoInfo: geoInfo}
+	}
+	var weatherEntry weatherCacheEntry
+	if json.Unmarshal(weatherData, &weatherEntry) != nil {
+		return cacheCheckResultMsg{hasCache: false, geoInfo: geoInfo}
+	}
+	if cacheExpired(weatherCacheFile, weatherEntry.FetchedAt, weatherTTL) {
+		return cacheCheckResultMsg{hasCache: false, geoInfo: geoInfo}
+	}
+
+	// Both caches are valid.
+	return cacheCheckResultMsg{
+		hasCache:    true,
+		geoInfo:     geoInfo,
+		weatherData: &weatherEntry.Weather,
+	}
+}
 
-		// If both caches are valid
-		return cacheCheckResultMsg{
-			hasCache:    true,
-			geoInfo:     &geoInfo,
-			weatherData: &weatherResp,
+// cacheExpired reports whether a cache entry is older than ttl. fetchedAt is
+// the source of truth; if it's unset (a cache file written before this field
+// existed), the file's ModTime() is used instead.
+func cacheExpired(path string, fetchedAt time.Time, ttl time.Duration) bool {
+	if fetchedAt.IsZero() {
+		if info, err := os.Stat(path); err == nil {
+			fetchedAt = info.ModTime()
 		}
 	}
+	return time.Since(fetchedAt) > ttl
+}
+
+// geoCacheFilePath and weatherCacheFilePath namespace the cache file by
+// backend, so switching --backend or --geo-backend can't serve stale data
+// fetched from a different provider.
+func geoCacheFilePath(backend GeoBackend) string {
+	return filepath.Join(cacheDir, fmt.Sprintf("geoinfo_cache_%s.json", backend.Name()))
 }
 
-func fetchPublicIPCmd() tea.Cmd {
+func weatherCacheFilePath(backend WeatherBackend) string {
+	return filepath.Join(cacheDir, fmt.Sprintf("weather_cache_%s.json", backend.Name()))
+}
+
+func fetchPublicIPCmd(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
-		ip, err := getPublicIP()
+		ip, err := getPublicIP(ctx)
 		if err != nil {
 			return errorMsg{err}
 		}
@@ -314,9 +1263,9 @@ func fetchPublicIPCmd() tea.Cmd {
 	}
 }
 
-func fetchGeoInfoCmd(ip string) tea.Cmd {
+func fetchGeoInfoCmd(ctx context.Context, ip string, backend GeoBackend) tea.Cmd {
 	return func() tea.Msg {
-		geoInfo, err := getGeoInfo(ip)
+		geoInfo, err := getGeoInfo(ctx, ip, backend)
 		if err != nil {
 			return errorMsg{err}
 		}
@@ -324,9 +1273,9 @@ func fetchGeoInfoCmd(ip string) tea.Cmd {
 	}
 }
 
-func fetchWeatherInfoCmd(geoInfo *GeoInfo) tea.Cmd {
+func fetchWeatherInfoCmd(ctx context.Context, geoInfo *GeoInfo, backend WeatherBackend) tea.Cmd {
 	return func() tea.Msg {
-		weatherData, err := getWeatherInfo(geoInfo)
+		weatherData, err := getWeatherInfo(ctx, geoInfo, backend)
 		if err != nil {
 			return errorMsg{err}
 		}
@@ -334,8 +1283,21 @@ func fetchWeatherInfoCmd(geoInfo *GeoInfo) tea.Cmd {
 	}
 }
 
-func getPublicIP() (string, error) {
-	resp, err := http.Get("https://api.ipify.org")
+// fetchWeatherInfoNoCacheCmd is fetchWeatherInfoCmd for the manual location
+// override paths, which must not write through the shared weatherCacheFilePath
+// (see getWeatherInfoNoCache).
+func fetchWeatherInfoNoCacheCmd(ctx context.Context, geoInfo *GeoInfo, backend WeatherBackend) tea.Cmd {
+	return func() tea.Msg {
+		weatherData, err := getWeatherInfoNoCache(ctx, geoInfo, backend)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return weatherFetchedMsg(weatherData)
+	}
+}
+
+func getPublicIP(ctx context.Context) (string, error) {
+	resp, err := httpGetWithRetry(ctx, "https://api.ipify.org")
 	if err != nil {
 		return "", err
 	}
@@ -347,71 +1309,371 @@ func getPublicIP() (string, error) {
 	return string(body), nil
 }
 
-func getGeoInfo(ip string) (*GeoInfo, error) {
-	url := fmt.Sprintf("http://ip-api.com/json/%s", ip)
-	resp, err := http.Get(url)
+func getGeoInfo(ctx context.Context, ip string, backend GeoBackend) (*GeoInfo, error) {
+	geoInfo, err := backend.Fetch(ctx, ip)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	httpBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading API response body: %w", err)
-	}
-	cacheFile := filepath.Join(os.TempDir(), "geoinfo_cache.json")
-	if err := os.WriteFile(cacheFile, httpBody, 0644); err != nil {
+
+	cacheFile := geoCacheFilePath(backend)
+	entry := geoCacheEntry{FetchedAt: time.Now(), GeoInfo: *geoInfo}
+	if cacheBody, err := json.Marshal(entry); err != nil {
+		log.Printf("warning: failed to marshal geolocation cache: %v", err)
+	} else if err := writeFileAtomic(cacheFile, cacheBody, 0644); err != nil {
 		log.Printf("warning: failed to write geolocation cache: %v", err)
 	}
-	var geoInfo GeoInfo
-	if err := json.Unmarshal(httpBody, &geoInfo); err != nil {
+	return geoInfo, nil
+}
+
+func getWeatherInfo(ctx context.Context, geoInfo *GeoInfo, backend WeatherBackend) (*WeatherResponse, error) {
+	weatherData, err := getWeatherInfoNoCache(ctx, geoInfo, backend)
+	if err != nil {
 		return nil, err
 	}
-	if geoInfo.Status != "success" {
-		return nil, fmt.Errorf("geolocation API failed with status: %s", geoInfo.Status)
+
+	cacheFile := weatherCacheFilePath(backend)
+	entry := weatherCacheEntry{FetchedAt: time.Now(), Weather: *weatherData}
+	if cacheBody, err := json.Marshal(entry); err != nil {
+		log.Printf("warning: failed to marshal weather cache: %v", err)
+	} else if err := writeFileAtomic(cacheFile, cacheBody, 0644); err != nil {
+		log.Printf("warning: failed to write weather cache: %v", err)
 	}
-	return &geoInfo, nil
+	return weatherData, nil
+}
+
+// getWeatherInfoNoCache fetches current conditions without touching
+// weatherCacheFilePath. It's used for manual location overrides
+// (--lat/--lon/--coords/--location): that cache file is keyed only by
+// backend name, not by location, so writing through it here would let an
+// override run for one city clobber the shared cache that a later plain
+// `tstats` invocation (IP-geolocated, a different city) reads from.
+func getWeatherInfoNoCache(ctx context.Context, geoInfo *GeoInfo, backend WeatherBackend) (*WeatherResponse, error) {
+	return backend.Fetch(ctx, geoInfo.Lat, geoInfo.Lon)
+}
+
+// fetchPipeline resolves location and current weather the same way the TUI
+// model's Init/Update chain does, but synchronously and without going
+// through Bubble Tea. It backs every --format value other than "tui".
+func fetchPipeline(ctx context.Context, forceRefresh bool, geoTTL, weatherTTL time.Duration, geoBackend GeoBackend, weatherBackend WeatherBackend, override *locationOverride) (*GeoInfo, *WeatherResponse, error) {
+	switch {
+	case override != nil && override.geoInfo != nil:
+		weatherData, err := getWeatherInfoNoCache(ctx, override.geoInfo, weatherBackend)
+		if err != nil {
+			return nil, nil, err
+		}
+		return override.geoInfo, weatherData, nil
+
+	case override != nil && override.query != "":
+		geoInfo, err := fetchGeocode(ctx, override.query)
+		if err != nil {
+			return nil, nil, err
+		}
+		weatherData, err := getWeatherInfoNoCache(ctx, geoInfo, weatherBackend)
+		if err != nil {
+			return nil, nil, err
+		}
+		return geoInfo, weatherData, nil
+
+	default:
+		result := checkCache(forceRefresh, geoTTL, weatherTTL, geoBackend, weatherBackend)
+		if result.hasCache {
+			return result.geoInfo, result.weatherData, nil
+		}
+
+		geoInfo := result.geoInfo
+		if geoInfo == nil {
+			ip, err := getPublicIP(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			geoInfo, err = getGeoInfo(ctx, ip, geoBackend)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		weatherData, err := getWeatherInfo(ctx, geoInfo, weatherBackend)
+		if err != nil {
+			return nil, nil, err
+		}
+		return geoInfo, weatherData, nil
+	}
+}
+
+// --- Headless Output Modes ---
+//
+// --format=json/oneline/prom skip the Bubble Tea program entirely and print
+// a single result, so tstats can be embedded in status bars or scraped by
+// cron/Prometheus instead of run interactively.
+
+// wmoCodeToEmoji maps a WMO weather code (the vocabulary WeatherResponse
+// normalizes every backend onto, see owmConditionToWMO/wttrCodeToWMO) to the
+// emoji wttr.in-style status bars expect.
+func wmoCodeToEmoji(code int) string {
+	switch {
+	case code == 0:
+		return "☀"
+	case code >= 1 && code <= 3:
+		return "⛅"
+	case code == 45 || code == 48:
+		return "🌫"
+	case code >= 51 && code <= 57:
+		return "🌦"
+	case (code >= 61 && code <= 67) || (code >= 80 && code <= 82):
+		return "🌧"
+	case (code >= 71 && code <= 77) || code == 85 || code == 86:
+		return "❄"
+	case code >= 95 && code <= 99:
+		return "⛈"
+	default:
+		return "❓"
+	}
+}
+
+// headlessOutput is the shape printed by --format=json: the geolocation and
+// weather results merged into one object.
+type headlessOutput struct {
+	Geo     GeoInfo         `json:"geo"`
+	Weather WeatherResponse `json:"weather"`
+}
+
+func printOneline(geoInfo *GeoInfo, weatherData *WeatherResponse) {
+	fmt.Printf("%s %.1f°C %s\n", geoInfo.City, weatherData.Current.Temperature2m, wmoCodeToEmoji(weatherData.Current.WeatherCode))
+}
+
+func printJSON(geoInfo *GeoInfo, weatherData *WeatherResponse) error {
+	return json.NewEncoder(os.Stdout).Encode(headlessOutput{Geo: *geoInfo, Weather: *weatherData})
 }
 
-func getWeatherInfo(geoInfo *GeoInfo) (*WeatherResponse, error) {
-	url := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f&current=temperature_2m,weather_code", geoInfo.Lat, geoInfo.Lon)
-	resp, err := http.Get(url)
+// printProm writes Prometheus text-exposition-format metrics, one line per
+// gauge, labeled by city so a scrape config can tell readings from different
+// locations apart.
+func printProm(geoInfo *GeoInfo, weatherData *WeatherResponse) {
+	current := weatherData.Current
+	fmt.Printf("tstats_temperature_celsius{city=%q} %.1f\n", geoInfo.City, current.Temperature2m)
+	fmt.Printf("tstats_humidity_percent{city=%q} %.1f\n", geoInfo.City, current.Humidity)
+	fmt.Printf("tstats_wind_speed_kmh{city=%q} %.1f\n", geoInfo.City, current.WindSpeed10m)
+}
+
+// runHeadless runs fetchPipeline and prints the result in the given format.
+// It never starts tea.NewProgram.
+func runHeadless(ctx context.Context, format string, forceRefresh bool, geoTTL, weatherTTL time.Duration, geoBackend GeoBackend, weatherBackend WeatherBackend, override *locationOverride) error {
+	geoInfo, weatherData, err := fetchPipeline(ctx, forceRefresh, geoTTL, weatherTTL, geoBackend, weatherBackend, override)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("weather API returned an unexpected status: %s", resp.Status)
+
+	switch format {
+	case "oneline":
+		printOneline(geoInfo, weatherData)
+	case "json":
+		return printJSON(geoInfo, weatherData)
+	case "prom":
+		printProm(geoInfo, weatherData)
 	}
-	httpBody, err := io.ReadAll(resp.Body)
+	return nil
+}
+
+// --- Cache Subcommand ---
+//
+// `tstats cache clear|info|path` lets users inspect and manage the on-disk
+// cache directly, instead of having to go digging through ~/.cache/tstats
+// by hand.
+
+// runCacheSubcommand implements `tstats cache <clear|info|path>`. It parses
+// its own flag set (just --cache-dir) since it runs instead of, not
+// alongside, the main flag set.
+func runCacheSubcommand(args []string) {
+	fs := flag.NewFlagSet("cache", flag.ExitOnError)
+	cacheDirFlag := fs.String("cache-dir", "", "cache directory (default: $XDG_CACHE_HOME/tstats or ~/.cache/tstats)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: tstats cache <clear|info|path>")
+		os.Exit(1)
+	}
+
+	dir, err := resolveCacheDir(*cacheDirFlag)
 	if err != nil {
-		return nil, fmt.Errorf("error reading API response body: %w", err)
+		fmt.Println("Error:", err)
+		os.Exit(1)
 	}
-	cacheFile := filepath.Join(os.TempDir(), "weather_cache.json")
-	if err := os.WriteFile(cacheFile, httpBody, 0644); err != nil {
-		log.Printf("warning: failed to write weather cache: %v", err)
+
+	switch fs.Arg(0) {
+	case "path":
+		fmt.Println(dir)
+	case "info":
+		if err := printCacheDirInfo(dir); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	case "clear":
+		if err := clearCacheDir(dir); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Cache cleared:", dir)
+	default:
+		fmt.Printf("Error: unknown cache subcommand %q (want clear, info, or path)\n", fs.Arg(0))
+		os.Exit(1)
 	}
-	var weatherData WeatherResponse
-	if err := json.Unmarshal(httpBody, &weatherData); err != nil {
-		return nil, fmt.Errorf("error decoding weather data: %w", err)
+}
+
+// printCacheDirInfo lists every cache file's size and age, so users can tell
+// whether a stale reading is due to an expired TTL without hunting through
+// the directory themselves.
+func printCacheDirInfo(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		fmt.Println("Cache is empty:", dir)
+		return nil
 	}
-	return &weatherData, nil
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("Cache is empty:", dir)
+		return nil
+	}
+
+	fmt.Println("Cache directory:", dir)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %-40s %8d bytes  age %s\n", entry.Name(), info.Size(), time.Since(info.ModTime()).Round(time.Second))
+	}
+	return nil
+}
+
+// clearCacheDir removes every file in dir, leaving the directory itself in
+// place. A missing directory is not an error: there's simply nothing to clear.
+func clearCacheDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // --- Main Function ---
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		runCacheSubcommand(os.Args[2:])
+		return
+	}
+
 	clearCache := flag.Bool("clear", false, "Force fetch new data by clearing the cache")
+	weatherTTL := flag.Duration("weather-ttl", 10*time.Minute, "how long cached weather data stays valid")
+	geoTTL := flag.Duration("geo-ttl", 24*time.Hour, "how long cached geolocation data stays valid")
+	backendName := flag.String("backend", "openmeteo", "weather backend to use: openmeteo, owm, or wttr")
+	geoBackendName := flag.String("geo-backend", "ipapi", "geolocation backend to use: ipapi, ipinfo, or ipwhois")
+	apiKey := flag.String("api-key", os.Getenv("OWM_API_KEY"), "OpenWeatherMap API key (required for --backend=owm)")
+	location := flag.String("location", "", `place name to resolve instead of using IP geolocation, e.g. "Berlin,DE"`)
+	lat := flag.Float64("lat", math.NaN(), "manual latitude, skips IP geolocation (requires --lon)")
+	lon := flag.Float64("lon", math.NaN(), "manual longitude, skips IP geolocation (requires --lat)")
+	coords := flag.String("coords", "", `manual "lat,lon" pair, skips IP geolocation`)
+	forecast := flag.String("forecast", "current", "forecast view to show: current, hourly, daily, or all")
+	format := flag.String("format", "tui", "output format: tui, json, oneline, or prom")
+	cacheDirFlag := flag.String("cache-dir", "", "cache directory (default: $XDG_CACHE_HOME/tstats or ~/.cache/tstats)")
 	flag.Parse()
 
-	if *clearCache {
+	resolvedCacheDir, err := resolveCacheDir(*cacheDirFlag)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(resolvedCacheDir, 0700); err != nil {
+		fmt.Println("Error: could not create cache directory:", err)
+		os.Exit(1)
+	}
+	cacheDir = resolvedCacheDir
+
+	switch *forecast {
+	case "current", "hourly", "daily", "all":
+	default:
+		fmt.Printf("Error: unknown --forecast %q (want current, hourly, daily, or all)\n", *forecast)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "tui", "json", "oneline", "prom":
+	default:
+		fmt.Printf("Error: unknown --format %q (want tui, json, oneline, or prom)\n", *format)
+		os.Exit(1)
+	}
+
+	if *clearCache && *format == "tui" {
 		// Create a faint style for the message
 		faintStyle := lipgloss.NewStyle().Faint(true)
 		// Render the message with the style and print it
 		fmt.Println(faintStyle.Render("Cache will be cleared on this run."))
 	}
 
-	if _, err := tea.NewProgram(newModel(*clearCache)).Run(); err != nil {
+	weatherBackend, err := selectWeatherBackend(*backendName, *apiKey)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	geoBackend, err := selectGeoBackend(*geoBackendName)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	override, err := buildLocationOverride(*coords, *lat, *lon, *location)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if *format != "tui" {
+		if err := runHeadless(ctx, *format, *clearCache, *geoTTL, *weatherTTL, geoBackend, weatherBackend, override); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if _, err := tea.NewProgram(newModel(ctx, cancel, *clearCache, *geoTTL, *weatherTTL, geoBackend, weatherBackend, override, *forecast)).Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
 }
+
+// buildLocationOverride resolves the --coords/--lat/--lon/--location flags
+// into a locationOverride. --coords and --lat/--lon take priority over
+// --location since they need no network round trip. Returns nil when none
+// of the flags were set, meaning IP-based geolocation should run as usual.
+func buildLocationOverride(coords string, lat, lon float64, location string) (*locationOverride, error) {
+	if coords != "" {
+		lat, lon, err := parseLatLon(coords)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --coords %q: %w", coords, err)
+		}
+		return &locationOverride{geoInfo: &GeoInfo{Status: "success", City: coords, Lat: lat, Lon: lon}}, nil
+	}
+	if !math.IsNaN(lat) || !math.IsNaN(lon) {
+		if math.IsNaN(lat) || math.IsNaN(lon) {
+			return nil, fmt.Errorf("--lat and --lon must be provided together")
+		}
+		city := fmt.Sprintf("%.4f,%.4f", lat, lon)
+		return &locationOverride{geoInfo: &GeoInfo{Status: "success", City: city, Lat: lat, Lon: lon}}, nil
+	}
+	if location != "" {
+		return &locationOverride{query: location}, nil
+	}
+	return nil, nil
+}